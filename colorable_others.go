@@ -0,0 +1,16 @@
+// +build !windows
+
+// Copyright 2013, Örjan Persson. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logging
+
+import "io"
+
+// newColorable returns out unchanged: every POSIX terminal go-logging
+// targets understands ANSI SGR sequences natively, so no translation layer
+// is needed outside of Windows.
+func newColorable(out io.Writer) io.Writer {
+	return out
+}
@@ -0,0 +1,240 @@
+// +build windows
+
+// Copyright 2013, Örjan Persson. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logging
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32DLL                 = syscall.NewLazyDLL("kernel32.dll")
+	getConsoleModeProc          = kernel32DLL.NewProc("GetConsoleMode")
+	setConsoleModeProc          = kernel32DLL.NewProc("SetConsoleMode")
+	setConsoleTextAttributeProc = kernel32DLL.NewProc("SetConsoleTextAttribute")
+)
+
+// enableVirtualTerminalProcessing lets a Windows 10+ console interpret ANSI
+// escape sequences natively, avoiding the translator below entirely.
+const enableVirtualTerminalProcessing = 0x0004
+
+// word mirrors the Windows console attribute type (WORD).
+type word uint16
+
+// Console foreground/background attribute bits. See
+// https://msdn.microsoft.com/en-us/library/windows/desktop/ms682088(v=vs.85).aspx#_win32_character_attributes.
+const (
+	fgBlue      word = 0x0001
+	fgGreen     word = 0x0002
+	fgRed       word = 0x0004
+	fgIntensity word = 0x0008
+	fgMask      word = 0x000F
+
+	bgBlue      word = 0x0010
+	bgGreen     word = 0x0020
+	bgRed       word = 0x0040
+	bgIntensity word = 0x0080
+	bgMask      word = 0x00F0
+
+	underscore word = 0x8000
+	reverse    word = 0x4000
+
+	defaultAttributes = fgRed | fgGreen | fgBlue
+)
+
+// ansiToWindows maps the SGR foreground color codes 30-37 to the equivalent
+// Windows console foreground bits. Background codes (40-47) reuse this table
+// shifted left by four; bright variants (90-97/100-107) additionally set the
+// matching *_INTENSITY bit.
+var ansiToWindows = map[int]word{
+	30: 0,
+	31: fgRed,
+	32: fgGreen,
+	33: fgRed | fgGreen,
+	34: fgBlue,
+	35: fgRed | fgBlue,
+	36: fgGreen | fgBlue,
+	37: fgRed | fgGreen | fgBlue,
+}
+
+// newColorable wraps out so ANSI SGR escape sequences written to it behave
+// correctly on Windows. If the console supports
+// ENABLE_VIRTUAL_TERMINAL_PROCESSING (Windows 10+), it is turned on and out
+// is returned unchanged; otherwise writes are routed through an ansiWriter
+// that translates SGR codes into SetConsoleTextAttribute calls.
+func newColorable(out io.Writer) io.Writer {
+	f, ok := out.(file)
+	if !ok {
+		return out
+	}
+	if enableNativeANSI(f) {
+		return out
+	}
+	return &ansiWriter{f: f, attr: defaultAttributes}
+}
+
+func enableNativeANSI(f file) bool {
+	var mode uint32
+	r, _, _ := getConsoleModeProc.Call(f.Fd(), uintptr(unsafe.Pointer(&mode)))
+	if r == 0 {
+		return false
+	}
+	r, _, _ = setConsoleModeProc.Call(f.Fd(), uintptr(mode|enableVirtualTerminalProcessing))
+	return r != 0
+}
+
+// ansiWriter intercepts ANSI SGR escape sequences and translates them into
+// SetConsoleTextAttribute calls for legacy Windows consoles that don't
+// support VT processing. Escape sequences split across Write calls are
+// buffered until they're complete.
+type ansiWriter struct {
+	f    file
+	attr word
+	buf  bytes.Buffer
+}
+
+func (w *ansiWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	data := append([]byte(nil), w.buf.Bytes()...)
+	w.buf.Reset()
+
+	var written int
+	for len(data) > 0 {
+		i := bytes.IndexByte(data, 0x1b)
+		if i < 0 {
+			n, err := w.writeChunk(data)
+			written += n
+			if err != nil {
+				w.buf.Write(data[n:])
+				return min(written, len(p)), err
+			}
+			break
+		}
+
+		if i > 0 {
+			n, err := w.writeChunk(data[:i])
+			written += n
+			if err != nil {
+				w.buf.Write(data[n:])
+				return min(written, len(p)), err
+			}
+			data = data[i:]
+		}
+
+		end := bytes.IndexByte(data, 'm')
+		if end < 0 {
+			// Incomplete sequence; keep it buffered for the next Write. It's
+			// fully accepted, just not yet flushed, so it still counts
+			// towards written.
+			written += len(data)
+			w.buf.Write(data)
+			break
+		}
+
+		if bytes.HasPrefix(data, []byte("\x1b[")) {
+			w.applySGR(string(data[2:end]))
+			written += end + 1
+			data = data[end+1:]
+			continue
+		}
+
+		n, err := w.writeChunk(data[:end+1])
+		written += n
+		if err != nil {
+			w.buf.Write(data[n:])
+			return min(written, len(p)), err
+		}
+		data = data[end+1:]
+	}
+	return min(written, len(p)), nil
+}
+
+// writeChunk flushes chunk to the console handle, normalizing a short write
+// with a nil error into io.ErrShortWrite so every non-nil return from flush
+// can be handled the same way by Write.
+func (w *ansiWriter) writeChunk(chunk []byte) (int, error) {
+	n, err := w.flush(chunk)
+	if err == nil && n < len(chunk) {
+		err = io.ErrShortWrite
+	}
+	return n, err
+}
+
+// flush writes p to the console handle, returning the real byte count and
+// error from syscall.Write so Write's own return value stays honest.
+func (w *ansiWriter) flush(p []byte) (int, error) {
+	return syscall.Write(syscall.Handle(w.f.Fd()), p)
+}
+
+func (w *ansiWriter) applySGR(codes string) {
+	if codes == "" {
+		codes = "0"
+	}
+	for _, part := range strings.Split(codes, ";") {
+		code, err := strconv.Atoi(part)
+		if err != nil {
+			continue
+		}
+		switch {
+		case code == 0:
+			w.attr = defaultAttributes
+		case code == 1:
+			w.attr |= fgIntensity
+		case code == 2:
+			w.attr &^= fgIntensity
+		case code == 4:
+			w.attr |= underscore
+		case code == 7:
+			w.attr |= reverse
+		case code == 22:
+			w.attr &^= fgIntensity
+		case code == 24:
+			w.attr &^= underscore
+		case code == 27:
+			w.attr &^= reverse
+		case code == 39:
+			w.attr = w.attr&^fgMask | defaultAttributes&fgMask
+		case code == 49:
+			w.attr = w.attr &^ bgMask
+		case code >= 30 && code <= 37:
+			w.attr = w.attr&^fgMask | ansiToWindows[code]
+		case code >= 90 && code <= 97:
+			w.attr = w.attr&^fgMask | ansiToWindows[code-60] | fgIntensity
+		case code >= 40 && code <= 47:
+			w.attr = w.attr&^bgMask | ansiToWindows[code-10]<<4
+		case code >= 100 && code <= 107:
+			w.attr = w.attr&^bgMask | ansiToWindows[code-70]<<4 | bgIntensity
+		}
+	}
+	setConsoleTextAttribute(w.f, w.attr)
+}
+
+// setConsoleTextAttribute sets the attributes of characters written to the
+// console screen buffer by the WriteFile or WriteConsole function.
+// See http://msdn.microsoft.com/en-us/library/windows/desktop/ms686047(v=vs.85).aspx.
+func setConsoleTextAttribute(f file, attribute word) error {
+	r1, r2, err := setConsoleTextAttributeProc.Call(f.Fd(), uintptr(attribute), 0)
+	return checkError(r1, r2, err)
+}
+
+// checkError evaluates the results of a Windows API call and returns the error if it failed.
+func checkError(r1, r2 uintptr, err error) error {
+	// Windows APIs return non-zero to indicate success
+	if r1 != 0 {
+		return nil
+	}
+
+	// Return the error if provided, otherwise default to EINVAL
+	if err != nil {
+		return err
+	}
+	return syscall.EINVAL
+}
@@ -0,0 +1,100 @@
+// +build windows
+
+// Copyright 2013, Örjan Persson. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logging
+
+import (
+	"os"
+	"testing"
+)
+
+// fakeFile satisfies the file interface without touching a real console, so
+// ansiWriter's parsing can be tested without a Windows console attached.
+type fakeFile struct{}
+
+func (fakeFile) Fd() uintptr { return 0 }
+
+// invalidFile is a file whose descriptor syscall.Write always rejects, so
+// ansiWriter.Write's error propagation can be tested.
+type invalidFile struct{}
+
+func (invalidFile) Fd() uintptr { return ^uintptr(0) }
+
+func TestAnsiWriterAppliesSGR(t *testing.T) {
+	w := &ansiWriter{f: fakeFile{}, attr: defaultAttributes}
+
+	w.applySGR("31")
+	if w.attr&fgMask != fgRed {
+		t.Errorf("attr&fgMask = %#x, want fgRed", w.attr&fgMask)
+	}
+
+	w.applySGR("0")
+	if w.attr != defaultAttributes {
+		t.Errorf("attr after reset = %#x, want defaultAttributes", w.attr)
+	}
+}
+
+func TestAnsiWriterBrightAndBackground(t *testing.T) {
+	w := &ansiWriter{f: fakeFile{}, attr: defaultAttributes}
+
+	w.applySGR("97;41")
+	if w.attr&fgMask != fgRed|fgGreen|fgBlue {
+		t.Errorf("fg = %#x, want white bits", w.attr&fgMask)
+	}
+	if w.attr&fgIntensity == 0 {
+		t.Error("bright foreground (97) should set fgIntensity")
+	}
+	if w.attr&bgMask != fgRed<<4 {
+		t.Errorf("bg = %#x, want red background bits", w.attr&bgMask)
+	}
+}
+
+func TestAnsiWriterBufferSplitAcrossWrites(t *testing.T) {
+	// Write now propagates real syscall errors, so the literal-text portions
+	// need a handle that can actually accept writes.
+	f, err := os.CreateTemp(t.TempDir(), "ansiwriter")
+	if err != nil {
+		t.Fatalf("CreateTemp: %v", err)
+	}
+	defer f.Close()
+
+	w := &ansiWriter{f: f, attr: defaultAttributes}
+
+	// Split the escape sequence itself across two Write calls.
+	if _, err := w.Write([]byte("hello \x1b[3")); err != nil {
+		t.Fatalf("first Write error: %v", err)
+	}
+	if w.attr != defaultAttributes {
+		t.Errorf("attr changed before the sequence completed: %#x", w.attr)
+	}
+
+	if _, err := w.Write([]byte("1mworld")); err != nil {
+		t.Fatalf("second Write error: %v", err)
+	}
+	if w.attr&fgMask != fgRed {
+		t.Errorf("attr after completing split sequence = %#x, want fgRed", w.attr&fgMask)
+	}
+}
+
+func TestAnsiWriterWritePropagatesSyscallError(t *testing.T) {
+	w := &ansiWriter{f: invalidFile{}, attr: defaultAttributes}
+
+	n, err := w.Write([]byte("hello"))
+	if err == nil {
+		t.Fatal("Write with an invalid handle should return an error")
+	}
+	if n != 0 {
+		t.Errorf("n = %d, want 0 since nothing was actually written", n)
+	}
+}
+
+func TestAnsiWriterIgnoresUnknownCodes(t *testing.T) {
+	w := &ansiWriter{f: fakeFile{}, attr: defaultAttributes}
+	w.applySGR("999")
+	if w.attr != defaultAttributes {
+		t.Errorf("unknown SGR code changed attr: %#x", w.attr)
+	}
+}
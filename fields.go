@@ -0,0 +1,89 @@
+// Copyright 2013, Örjan Persson. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Field is a single structured key/value pair attached to a Record via
+// Logger.With or one of the *w logging methods (Debugw, Infow, ...).
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// fieldsFromKeyvals pairs up a flat (key, value, key, value, ...) slice into
+// Fields. A trailing key without a matching value is paired with "MISSING".
+func fieldsFromKeyvals(keyvals []interface{}) []Field {
+	fields := make([]Field, 0, len(keyvals)/2+1)
+	for i := 0; i < len(keyvals); i += 2 {
+		key := fmt.Sprint(keyvals[i])
+		if i+1 >= len(keyvals) {
+			fields = append(fields, Field{Key: key, Value: "MISSING"})
+			break
+		}
+		fields = append(fields, Field{Key: key, Value: keyvals[i+1]})
+	}
+	return fields
+}
+
+// With returns a child Logger whose Records always carry keyvals in addition
+// to any fields already attached to l, so callers can build up context
+// incrementally (e.g. logger.With("request_id", id).With("user", u)).
+func (l *Logger) With(keyvals ...interface{}) *Logger {
+	child := *l
+	child.fields = append(append([]Field(nil), l.fields...), fieldsFromKeyvals(keyvals)...)
+	return &child
+}
+
+func (l *Logger) Debugw(msg string, keyvals ...interface{})    { l.logw(DEBUG, msg, keyvals) }
+func (l *Logger) Infow(msg string, keyvals ...interface{})     { l.logw(INFO, msg, keyvals) }
+func (l *Logger) Noticew(msg string, keyvals ...interface{})   { l.logw(NOTICE, msg, keyvals) }
+func (l *Logger) Warningw(msg string, keyvals ...interface{})  { l.logw(WARNING, msg, keyvals) }
+func (l *Logger) Errorw(msg string, keyvals ...interface{})    { l.logw(ERROR, msg, keyvals) }
+func (l *Logger) Criticalw(msg string, keyvals ...interface{}) { l.logw(CRITICAL, msg, keyvals) }
+
+func (l *Logger) logw(level Level, msg string, keyvals []interface{}) {
+	fields := append(append([]Field(nil), l.fields...), fieldsFromKeyvals(keyvals)...)
+	rec := &Record{Time: time.Now(), Module: l.Module, Level: level, Fields: fields}
+	rec.setMessage(msg)
+	l.backend.Log(level, 2+l.ExtraCalldepth, rec)
+}
+
+// formatFields renders fields as "key=value" pairs separated by spaces. When
+// colorize is set, the key and "=" separator are dimmed (SGR faint) so the
+// value stands out; values containing newlines wrap onto continuation lines
+// prefixed by a dimmed " | " marker so the whole field stays visually
+// grouped with the record it belongs to.
+func formatFields(fields []Field, colorize bool) string {
+	var buf bytes.Buffer
+	for i, f := range fields {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		if colorize {
+			fmt.Fprintf(&buf, "\x1b[2m%s=\x1b[22m", f.Key)
+		} else {
+			fmt.Fprintf(&buf, "%s=", f.Key)
+		}
+
+		value := fmt.Sprint(f.Value)
+		lines := strings.Split(value, "\n")
+		buf.WriteString(lines[0])
+		for _, line := range lines[1:] {
+			if colorize {
+				buf.WriteString("\n\x1b[2m | \x1b[22m")
+			} else {
+				buf.WriteString("\n | ")
+			}
+			buf.WriteString(line)
+		}
+	}
+	return buf.String()
+}
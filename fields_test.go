@@ -0,0 +1,86 @@
+// Copyright 2013, Örjan Persson. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logging
+
+import "testing"
+
+func TestFieldsFromKeyvals(t *testing.T) {
+	fields := fieldsFromKeyvals([]interface{}{"a", 1, "b", "two"})
+	want := []Field{{Key: "a", Value: 1}, {Key: "b", Value: "two"}}
+	if len(fields) != len(want) {
+		t.Fatalf("len = %d, want %d", len(fields), len(want))
+	}
+	for i := range want {
+		if fields[i] != want[i] {
+			t.Errorf("fields[%d] = %+v, want %+v", i, fields[i], want[i])
+		}
+	}
+}
+
+func TestFieldsFromKeyvalsOddCount(t *testing.T) {
+	fields := fieldsFromKeyvals([]interface{}{"a", 1, "dangling"})
+	want := Field{Key: "dangling", Value: "MISSING"}
+	if got := fields[len(fields)-1]; got != want {
+		t.Errorf("trailing field = %+v, want %+v", got, want)
+	}
+}
+
+func TestFormatFieldsPlain(t *testing.T) {
+	fields := []Field{{Key: "user", Value: "alice"}, {Key: "id", Value: 42}}
+	got := formatFields(fields, false)
+	want := "user=alice id=42"
+	if got != want {
+		t.Errorf("formatFields = %q, want %q", got, want)
+	}
+}
+
+func TestFormatFieldsColorized(t *testing.T) {
+	fields := []Field{{Key: "user", Value: "alice"}}
+	got := formatFields(fields, true)
+	want := "\x1b[2muser=\x1b[22malice"
+	if got != want {
+		t.Errorf("formatFields = %q, want %q", got, want)
+	}
+}
+
+func TestFormatFieldsMultilineValue(t *testing.T) {
+	fields := []Field{{Key: "stack", Value: "line1\nline2"}}
+	got := formatFields(fields, false)
+	want := "stack=line1\n | line2"
+	if got != want {
+		t.Errorf("formatFields = %q, want %q", got, want)
+	}
+}
+
+func TestLoggerWithAccumulatesFields(t *testing.T) {
+	var captured *Record
+	backend := backendFunc(func(level Level, calldepth int, rec *Record) error {
+		captured = rec
+		return nil
+	})
+
+	base := NewLogger("test", backend)
+	child := base.With("request_id", "r1").With("user", "alice")
+	child.Infow("hello")
+
+	if len(captured.Fields) != 2 {
+		t.Fatalf("len(Fields) = %d, want 2", len(captured.Fields))
+	}
+	if captured.Fields[0].Key != "request_id" || captured.Fields[1].Key != "user" {
+		t.Errorf("Fields = %+v, want request_id then user", captured.Fields)
+	}
+
+	// The parent Logger must be unaffected by the child's With calls.
+	base.Infow("bare")
+	if len(captured.Fields) != 0 {
+		t.Errorf("parent logger fields leaked: %+v", captured.Fields)
+	}
+}
+
+type backendFunc func(level Level, calldepth int, rec *Record) error
+
+func (f backendFunc) Log(level Level, calldepth int, rec *Record) error {
+	return f(level, calldepth, rec)
+}
@@ -0,0 +1,88 @@
+// Copyright 2013, Örjan Persson. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Formatter turns a Record into the text a Backend should emit. calldepth is
+// the frame offset to use for any verb that needs the caller's location;
+// colorize tells color-capable verbs (currently just %{fields}) whether to
+// emit ANSI styling.
+type Formatter interface {
+	Format(calldepth int, r *Record, output *bytes.Buffer, colorize bool) error
+}
+
+var verbRe = regexp.MustCompile(`%{(\w+)}`)
+
+// stringFormatter is a Formatter driven by %{verb} tokens in a layout
+// string: %{time}, %{id}, %{module}, %{level}, %{message}, %{fields},
+// %{shortfile} and %{longfile}. Unrecognized verbs are left untouched.
+type stringFormatter struct {
+	layout string
+}
+
+// NewStringFormatter compiles layout into a Formatter.
+func NewStringFormatter(layout string) Formatter {
+	return &stringFormatter{layout: layout}
+}
+
+// MustStringFormatter is like NewStringFormatter; kept for parity since
+// layouts here can't actually fail to compile.
+func MustStringFormatter(layout string) Formatter {
+	return NewStringFormatter(layout)
+}
+
+// DefaultFormatter is used by LogBackend when no Formatter has been set
+// explicitly.
+var DefaultFormatter = NewStringFormatter("%{module} %{level} %{message}%{fields}")
+
+func (f *stringFormatter) Format(calldepth int, r *Record, output *bytes.Buffer, colorize bool) error {
+	// The caller is resolved here, since expand runs beneath regexp's own
+	// frames and calldepth is only meaningful relative to this one.
+	_, file, line, ok := runtime.Caller(calldepth + 1)
+	output.WriteString(verbRe.ReplaceAllStringFunc(f.layout, func(token string) string {
+		return f.expand(token[2:len(token)-1], r, colorize, file, line, ok)
+	}))
+	return nil
+}
+
+func (f *stringFormatter) expand(verb string, r *Record, colorize bool, file string, line int, ok bool) string {
+	switch verb {
+	case "time":
+		return r.Time.Format(time.RFC3339)
+	case "id":
+		return fmt.Sprintf("%d", r.Id)
+	case "module":
+		return r.Module
+	case "level":
+		return r.Level.String()
+	case "message":
+		return r.Message()
+	case "fields":
+		if len(r.Fields) == 0 {
+			return ""
+		}
+		return " " + formatFields(r.Fields, colorize)
+	case "shortfile", "longfile":
+		if !ok {
+			return "???"
+		}
+		if verb == "shortfile" {
+			if i := strings.LastIndexByte(file, '/'); i >= 0 {
+				file = file[i+1:]
+			}
+		}
+		return fmt.Sprintf("%s:%d", file, line)
+	default:
+		return "%{" + verb + "}"
+	}
+}
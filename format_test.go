@@ -0,0 +1,60 @@
+// Copyright 2013, Örjan Persson. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logging
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestStringFormatterFieldsVerbPosition(t *testing.T) {
+	rec := &Record{Module: "mod", Level: INFO, Fields: []Field{{Key: "k", Value: "v"}}}
+	rec.setMessage("hello")
+
+	f := NewStringFormatter("%{fields} :: %{message}")
+	var buf bytes.Buffer
+	if err := f.Format(1, rec, &buf, false); err != nil {
+		t.Fatalf("Format() error: %v", err)
+	}
+	if want := " k=v :: hello"; buf.String() != want {
+		t.Errorf("Format() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestStringFormatterFieldsVerbOmittedWhenNoFields(t *testing.T) {
+	rec := &Record{Module: "mod", Level: INFO}
+	rec.setMessage("hello")
+
+	f := NewStringFormatter("%{message}%{fields}")
+	var buf bytes.Buffer
+	f.Format(1, rec, &buf, false)
+	if want := "hello"; buf.String() != want {
+		t.Errorf("Format() = %q, want %q (no trailing space/verb leakage)", buf.String(), want)
+	}
+}
+
+func TestStringFormatterFieldsVerbCanBeOmittedEntirely(t *testing.T) {
+	rec := &Record{Module: "mod", Level: INFO, Fields: []Field{{Key: "k", Value: "v"}}}
+	rec.setMessage("hello")
+
+	f := NewStringFormatter("%{message}")
+	var buf bytes.Buffer
+	f.Format(1, rec, &buf, false)
+	if want := "hello"; buf.String() != want {
+		t.Errorf("Format() = %q, want %q (fields must not leak in without the verb)", buf.String(), want)
+	}
+}
+
+func TestStringFormatterFieldsVerbColorized(t *testing.T) {
+	rec := &Record{Module: "mod", Level: INFO, Fields: []Field{{Key: "k", Value: "v"}}}
+	rec.setMessage("hello")
+
+	f := NewStringFormatter("%{message}%{fields}")
+	var buf bytes.Buffer
+	f.Format(1, rec, &buf, true)
+	if want := "hello \x1b[2mk=\x1b[22mv"; buf.String() != want {
+		t.Errorf("Format() = %q, want %q", buf.String(), want)
+	}
+}
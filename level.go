@@ -0,0 +1,51 @@
+// Copyright 2013, Örjan Persson. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logging
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidLogLevel is returned by LevelFromString for a name that doesn't
+// match any Level.
+var ErrInvalidLogLevel = errors.New("logging: invalid log level")
+
+// Level defines the severity of a log record, from CRITICAL (most severe)
+// to DEBUG (least severe).
+type Level int
+
+const (
+	CRITICAL Level = iota
+	ERROR
+	WARNING
+	NOTICE
+	INFO
+	DEBUG
+)
+
+var levelNames = []string{
+	CRITICAL: "CRITICAL",
+	ERROR:    "ERROR",
+	WARNING:  "WARNING",
+	NOTICE:   "NOTICE",
+	INFO:     "INFO",
+	DEBUG:    "DEBUG",
+}
+
+// String returns the name of the level, e.g. "DEBUG".
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+// LevelFromString returns the Level whose name matches name, case-insensitively.
+func LevelFromString(name string) (Level, error) {
+	for i, candidate := range levelNames {
+		if strings.EqualFold(candidate, name) {
+			return Level(i), nil
+		}
+	}
+	return ERROR, ErrInvalidLogLevel
+}
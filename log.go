@@ -0,0 +1,110 @@
+// Copyright 2013, Örjan Persson. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os"
+)
+
+// file is implemented by the writers (such as *os.File) that expose the
+// underlying descriptor needed to query and manipulate console attributes
+// on Windows.
+type file interface {
+	Fd() uintptr
+}
+
+const ansiReset = "\x1b[0m"
+
+// LogBackend utilizes the standard log module.
+//
+// Color output is written as plain ANSI SGR escape sequences from a single
+// code path on every platform. newColorable takes care of the platform
+// differences: on a Windows console that doesn't understand VT processing
+// natively, it wraps out so those sequences are translated into
+// SetConsoleTextAttribute calls instead.
+type LogBackend struct {
+	Logger *log.Logger
+
+	// Color enables colorized output when the destination is a terminal.
+	Color bool
+
+	// ForceColor enables colorized output unconditionally, bypassing the
+	// terminal auto-detection Color relies on.
+	ForceColor bool
+
+	// DisableColor disables colorized output unconditionally, even if Color
+	// or ForceColor is set.
+	DisableColor bool
+
+	isTerminal bool
+
+	// styles holds a per-Level override set by SetLevelStyle; nil until the
+	// first call, at which point it starts from defaultStyles.
+	styles []Style
+
+	// Format controls how a Record is rendered, including where %{fields}
+	// places structured field output. Defaults to DefaultFormatter.
+	Format Formatter
+}
+
+// NewLogBackend creates a new LogBackend.
+func NewLogBackend(out io.Writer, prefix string, flag int) *LogBackend {
+	return &LogBackend{
+		Logger:     log.New(newColorable(out), prefix, flag),
+		isTerminal: isTerminal(out),
+	}
+}
+
+func (b *LogBackend) colorize() bool {
+	if b.DisableColor {
+		return false
+	}
+	return b.ForceColor || (b.Color && b.isTerminal)
+}
+
+func (b *LogBackend) formatter() Formatter {
+	if b.Format != nil {
+		return b.Format
+	}
+	return DefaultFormatter
+}
+
+func (b *LogBackend) Log(level Level, calldepth int, rec *Record) error {
+	colorize := b.colorize()
+
+	buf := &bytes.Buffer{}
+	if colorize {
+		fmt.Fprintf(buf, "\x1b[%sm", sgr(b.styleFor(level)))
+	}
+	b.formatter().Format(calldepth+1, rec, buf, colorize)
+	if colorize {
+		buf.WriteString(ansiReset)
+	}
+
+	// For some reason, the Go logger arbitrarily decided "2" was the correct
+	// call depth...
+	return b.Logger.Output(calldepth+2, buf.String())
+}
+
+// isTerminal reports whether out is attached to a terminal, so redirected
+// output (files, pipes) automatically has color stripped.
+func isTerminal(out io.Writer) bool {
+	f, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+func doFmtVerbLevelColor(layout string, level Level, output io.Writer) {
+}
@@ -0,0 +1,115 @@
+// Copyright 2013, Örjan Persson. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestJSONBackendBasicKeys(t *testing.T) {
+	var buf bytes.Buffer
+	b := NewJSONBackend(&buf)
+
+	rec := &Record{Time: time.Now(), Module: "mymod", Level: WARNING}
+	rec.setMessage("something happened")
+	if err := b.Log(WARNING, 1, rec); err != nil {
+		t.Fatalf("Log() error: %v", err)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if entry["level"] != "warning" {
+		t.Errorf("level = %v, want %q", entry["level"], "warning")
+	}
+	if entry["module"] != "mymod" {
+		t.Errorf("module = %v, want %q", entry["module"], "mymod")
+	}
+	if entry["message"] != "something happened" {
+		t.Errorf("message = %v, want the raw message, not a formatted line", entry["message"])
+	}
+	if _, ok := entry["time"]; !ok {
+		t.Error("missing time key")
+	}
+}
+
+func TestJSONBackendTimeKeyOverride(t *testing.T) {
+	var buf bytes.Buffer
+	b := NewJSONBackend(&buf)
+	b.TimeKey = "@timestamp"
+
+	rec := &Record{Time: time.Now(), Module: "m", Level: INFO}
+	rec.setMessage("hi")
+	if err := b.Log(INFO, 1, rec); err != nil {
+		t.Fatalf("Log() error: %v", err)
+	}
+
+	var entry map[string]interface{}
+	json.Unmarshal(buf.Bytes(), &entry)
+	if _, ok := entry["@timestamp"]; !ok {
+		t.Error("missing @timestamp key")
+	}
+	if _, ok := entry["time"]; ok {
+		t.Error("unexpected default time key present alongside override")
+	}
+}
+
+func TestJSONBackendFieldsOverrideStaticAndDerivedKeys(t *testing.T) {
+	var buf bytes.Buffer
+	b := NewJSONBackend(&buf)
+	b.StaticFields = map[string]interface{}{"host": "box1", "module": "static-module"}
+
+	rec := &Record{
+		Time:   time.Now(),
+		Module: "real-module",
+		Level:  INFO,
+		Fields: []Field{{Key: "module", Value: "field-module"}, {Key: "host", Value: "field-host"}},
+	}
+	rec.setMessage("hi")
+	if err := b.Log(INFO, 1, rec); err != nil {
+		t.Fatalf("Log() error: %v", err)
+	}
+
+	var entry map[string]interface{}
+	json.Unmarshal(buf.Bytes(), &entry)
+
+	// User-supplied Fields take precedence over both StaticFields and the
+	// backend's own derived keys (time/level/module/caller).
+	if entry["module"] != "field-module" {
+		t.Errorf("module = %v, want field value to win", entry["module"])
+	}
+	if entry["host"] != "field-host" {
+		t.Errorf("host = %v, want field value to win over StaticFields", entry["host"])
+	}
+}
+
+func TestJSONBackendOneObjectPerLine(t *testing.T) {
+	var buf bytes.Buffer
+	b := NewJSONBackend(&buf)
+
+	for i := 0; i < 2; i++ {
+		rec := &Record{Time: time.Now(), Module: "m", Level: INFO}
+		rec.setMessage("line")
+		if err := b.Log(INFO, 1, rec); err != nil {
+			t.Fatalf("Log() error: %v", err)
+		}
+	}
+
+	lines := bytes.Split(bytes.TrimRight(buf.Bytes(), "\n"), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	for _, line := range lines {
+		var entry map[string]interface{}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			t.Errorf("line %q is not a single JSON object: %v", line, err)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2013, Örjan Persson. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logging
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Color identifies one of the 16 standard ANSI terminal colors a Style can
+// use for its foreground or background.
+type Color int
+
+// The 8 base colors plus their bright variants. ColorDefault leaves the
+// terminal's own default color untouched.
+const (
+	ColorDefault Color = iota
+	ColorBlack
+	ColorRed
+	ColorGreen
+	ColorYellow
+	ColorBlue
+	ColorMagenta
+	ColorCyan
+	ColorWhite
+	ColorBrightBlack
+	ColorBrightRed
+	ColorBrightGreen
+	ColorBrightYellow
+	ColorBrightBlue
+	ColorBrightMagenta
+	ColorBrightCyan
+	ColorBrightWhite
+)
+
+// Attribute is a bitmask of text attributes that can be combined with a
+// Style's colors.
+type Attribute int
+
+const (
+	AttrBold Attribute = 1 << iota
+	AttrFaint
+	AttrUnderline
+	AttrReverse
+)
+
+// Style describes how a Level should be rendered: its foreground and
+// background Color plus any combination of Attribute bits.
+type Style struct {
+	Fg    Color
+	Bg    Color
+	Attrs Attribute
+}
+
+// defaultStyles mirrors the colors LogBackend has always used, one per
+// Level, before any SetLevelStyle call customizes them.
+var defaultStyles = []Style{
+	CRITICAL: {Fg: ColorMagenta},
+	ERROR:    {Fg: ColorRed},
+	WARNING:  {Fg: ColorYellow},
+	NOTICE:   {Fg: ColorGreen},
+	INFO:     {Fg: ColorWhite},
+	DEBUG:    {Fg: ColorCyan},
+}
+
+// SetLevelStyle overrides the Style used to render level. It may be called
+// at any time and takes effect on the next Log call.
+func (b *LogBackend) SetLevelStyle(level Level, style Style) {
+	if b.styles == nil {
+		b.styles = append([]Style(nil), defaultStyles...)
+	}
+	b.styles[level] = style
+}
+
+func (b *LogBackend) styleFor(level Level) Style {
+	if b.styles != nil {
+		return b.styles[level]
+	}
+	return defaultStyles[level]
+}
+
+// ansiFgCode returns the ANSI SGR foreground code for c.
+func ansiFgCode(c Color) int {
+	switch {
+	case c == ColorDefault:
+		return 39
+	case c >= ColorBrightBlack:
+		return 90 + int(c-ColorBrightBlack)
+	default:
+		return 30 + int(c-ColorBlack)
+	}
+}
+
+// ansiBgCode returns the ANSI SGR background code for c.
+func ansiBgCode(c Color) int {
+	switch {
+	case c == ColorDefault:
+		return 49
+	case c >= ColorBrightBlack:
+		return 100 + int(c-ColorBrightBlack)
+	default:
+		return 40 + int(c-ColorBlack)
+	}
+}
+
+// sgr renders style as the body of an ANSI CSI sequence, e.g. "0;1;31".
+func sgr(style Style) string {
+	codes := []string{"0"}
+	if style.Attrs&AttrBold != 0 {
+		codes = append(codes, "1")
+	}
+	if style.Attrs&AttrFaint != 0 {
+		codes = append(codes, "2")
+	}
+	if style.Attrs&AttrUnderline != 0 {
+		codes = append(codes, "4")
+	}
+	if style.Attrs&AttrReverse != 0 {
+		codes = append(codes, "7")
+	}
+	if style.Fg != ColorDefault {
+		codes = append(codes, strconv.Itoa(ansiFgCode(style.Fg)))
+	}
+	if style.Bg != ColorDefault {
+		codes = append(codes, strconv.Itoa(ansiBgCode(style.Bg)))
+	}
+	return strings.Join(codes, ";")
+}
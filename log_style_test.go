@@ -0,0 +1,80 @@
+// Copyright 2013, Örjan Persson. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logging
+
+import "testing"
+
+func TestAnsiFgCode(t *testing.T) {
+	cases := []struct {
+		color Color
+		want  int
+	}{
+		{ColorDefault, 39},
+		{ColorBlack, 30},
+		{ColorWhite, 37},
+		{ColorBrightBlack, 90},
+		{ColorBrightWhite, 97},
+	}
+	for _, c := range cases {
+		if got := ansiFgCode(c.color); got != c.want {
+			t.Errorf("ansiFgCode(%v) = %d, want %d", c.color, got, c.want)
+		}
+	}
+}
+
+func TestAnsiBgCode(t *testing.T) {
+	cases := []struct {
+		color Color
+		want  int
+	}{
+		{ColorDefault, 49},
+		{ColorRed, 41},
+		{ColorBrightRed, 101},
+	}
+	for _, c := range cases {
+		if got := ansiBgCode(c.color); got != c.want {
+			t.Errorf("ansiBgCode(%v) = %d, want %d", c.color, got, c.want)
+		}
+	}
+}
+
+func TestSGR(t *testing.T) {
+	cases := []struct {
+		name  string
+		style Style
+		want  string
+	}{
+		{"plain", Style{}, "0"},
+		{"fg only", Style{Fg: ColorRed}, "0;31"},
+		{"fg and bg", Style{Fg: ColorWhite, Bg: ColorRed}, "0;37;41"},
+		{"bold bright fg", Style{Fg: ColorBrightWhite, Attrs: AttrBold}, "0;1;97"},
+		{"faint underline reverse", Style{Attrs: AttrFaint | AttrUnderline | AttrReverse}, "0;2;4;7"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := sgr(c.style); got != c.want {
+				t.Errorf("sgr(%+v) = %q, want %q", c.style, got, c.want)
+			}
+		})
+	}
+}
+
+func TestSetLevelStyle(t *testing.T) {
+	b := &LogBackend{}
+	if got := b.styleFor(ERROR); got != defaultStyles[ERROR] {
+		t.Fatalf("styleFor(ERROR) before override = %+v, want default %+v", got, defaultStyles[ERROR])
+	}
+
+	custom := Style{Fg: ColorBrightWhite, Bg: ColorRed, Attrs: AttrBold}
+	b.SetLevelStyle(ERROR, custom)
+	if got := b.styleFor(ERROR); got != custom {
+		t.Errorf("styleFor(ERROR) after override = %+v, want %+v", got, custom)
+	}
+	// Other levels must keep their defaults; SetLevelStyle must not clobber
+	// the whole table.
+	if got := b.styleFor(DEBUG); got != defaultStyles[DEBUG] {
+		t.Errorf("styleFor(DEBUG) = %+v, want untouched default %+v", got, defaultStyles[DEBUG])
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2013, Örjan Persson. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logging
+
+import "time"
+
+// Backend is the interface implemented by logging backends such as
+// LogBackend and JSONBackend.
+type Backend interface {
+	Log(level Level, calldepth int, rec *Record) error
+}
+
+// Logger is the logging handle applications use. Every logging method
+// (Debug, Infow, ...) builds a Record and hands it to backend.
+type Logger struct {
+	Module string
+
+	backend Backend
+
+	// ExtraCalldepth adjusts the calldepth passed to backend.Log, for
+	// callers that wrap Logger in their own helper functions.
+	ExtraCalldepth int
+
+	// fields holds context attached via With, carried onto every Record
+	// this Logger (and its children) produce.
+	fields []Field
+}
+
+// NewLogger creates a Logger for module, logging through backend.
+func NewLogger(module string, backend Backend) *Logger {
+	return &Logger{Module: module, backend: backend}
+}
+
+func (l *Logger) log(level Level, format string, args []interface{}) {
+	rec := &Record{
+		Time:   time.Now(),
+		Module: l.Module,
+		Level:  level,
+		Fields: l.fields,
+		fmt:    format,
+		args:   args,
+	}
+	l.backend.Log(level, 2+l.ExtraCalldepth, rec)
+}
+
+func (l *Logger) Debug(format string, args ...interface{})    { l.log(DEBUG, format, args) }
+func (l *Logger) Info(format string, args ...interface{})     { l.log(INFO, format, args) }
+func (l *Logger) Notice(format string, args ...interface{})   { l.log(NOTICE, format, args) }
+func (l *Logger) Warning(format string, args ...interface{})  { l.log(WARNING, format, args) }
+func (l *Logger) Error(format string, args ...interface{})    { l.log(ERROR, format, args) }
+func (l *Logger) Critical(format string, args ...interface{}) { l.log(CRITICAL, format, args) }
@@ -0,0 +1,43 @@
+// Copyright 2013, Örjan Persson. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logging
+
+import (
+	"fmt"
+	"time"
+)
+
+// Record represents a single log record, carrying everything a Backend
+// needs to format and emit it.
+type Record struct {
+	Id     uint64
+	Time   time.Time
+	Module string
+	Level  Level
+
+	// Fields holds any structured key/value pairs attached via Logger.With
+	// or the *w logging methods (Debugw, Infow, ...).
+	Fields []Field
+
+	message string
+	fmt     string
+	args    []interface{}
+}
+
+// Message returns the record's formatted message, computing it from fmt/args
+// on first access and memoizing the result.
+func (r *Record) Message() string {
+	if r.message == "" && r.fmt != "" {
+		r.message = fmt.Sprintf(r.fmt, r.args...)
+	}
+	return r.message
+}
+
+// setMessage sets a precomputed, literal message, bypassing fmt/args
+// formatting. Used by the *w logging methods (Debugw, Infow, ...), whose msg
+// argument is never a Printf-style format string.
+func (r *Record) setMessage(msg string) {
+	r.message = msg
+}